@@ -8,9 +8,6 @@ package akamai
 import (
 	"context"
 	"fmt"
-	"net/netip"
-	"strconv"
-	"strings"
 
 	"github.com/cosi-project/runtime/pkg/safe"
 	"github.com/cosi-project/runtime/pkg/state"
@@ -23,9 +20,7 @@ import (
 	"github.com/siderolabs/talos/pkg/machinery/constants"
 	"github.com/siderolabs/talos/pkg/machinery/imager/quirks"
 	"github.com/siderolabs/talos/pkg/machinery/meta"
-	"github.com/siderolabs/talos/pkg/machinery/nethelpers"
 	"github.com/siderolabs/talos/pkg/machinery/resources/hardware"
-	"github.com/siderolabs/talos/pkg/machinery/resources/network"
 	runtimeres "github.com/siderolabs/talos/pkg/machinery/resources/runtime"
 )
 
@@ -37,116 +32,6 @@ func (a *Akamai) Name() string {
 	return "akamai"
 }
 
-// ParseMetadata converts Akamai platform metadata into platform network config.
-func (a *Akamai) ParseMetadata(metadata *akametadata.InstanceData, interfaceAddresses *akametadata.NetworkData) (*runtime.PlatformNetworkConfig, error) {
-	networkConfig := &runtime.PlatformNetworkConfig{}
-
-	if metadata.Label != "" {
-		hostnameSpec := network.HostnameSpecSpec{
-			ConfigLayer: network.ConfigPlatform,
-		}
-
-		if err := hostnameSpec.ParseFQDN(metadata.Label); err != nil {
-			return nil, err
-		}
-
-		networkConfig.Hostnames = append(networkConfig.Hostnames, hostnameSpec)
-	}
-
-	publicIPs := make([]string, 0, len(interfaceAddresses.IPv4.Public)+len(interfaceAddresses.IPv6.Ranges))
-
-	// external IP
-	for _, iface := range interfaceAddresses.IPv4.Public {
-		publicIPs = append(publicIPs, iface.Addr().String())
-		networkConfig.Addresses = append(networkConfig.Addresses,
-			network.AddressSpecSpec{
-				ConfigLayer: network.ConfigPlatform,
-				LinkName:    "eth0",
-				Address:     iface,
-				Scope:       nethelpers.ScopeGlobal,
-				Flags:       nethelpers.AddressFlags(nethelpers.AddressPermanent),
-				Family:      nethelpers.FamilyInet4,
-			},
-		)
-	}
-
-	for _, iface := range interfaceAddresses.IPv4.Private {
-		networkConfig.Addresses = append(networkConfig.Addresses,
-			network.AddressSpecSpec{
-				ConfigLayer: network.ConfigPlatform,
-				LinkName:    "eth0",
-				Address:     iface,
-				Scope:       nethelpers.ScopeGlobal,
-				Flags:       nethelpers.AddressFlags(nethelpers.AddressPermanent),
-				Family:      nethelpers.FamilyInet4,
-			},
-		)
-	}
-
-	for _, iface := range interfaceAddresses.IPv6.Ranges {
-		publicIPs = append(publicIPs, iface.Addr().String())
-
-		networkConfig.Addresses = append(networkConfig.Addresses,
-			network.AddressSpecSpec{
-				ConfigLayer: network.ConfigPlatform,
-				LinkName:    "eth0",
-				Address:     iface,
-				Scope:       nethelpers.ScopeGlobal,
-				Flags:       nethelpers.AddressFlags(nethelpers.AddressManagementTemp),
-				Family:      nethelpers.FamilyInet6,
-			},
-		)
-	}
-
-	networkConfig.Addresses = append(networkConfig.Addresses,
-		network.AddressSpecSpec{
-			ConfigLayer: network.ConfigPlatform,
-			LinkName:    "eth0",
-			Address:     interfaceAddresses.IPv6.LinkLocal,
-			Scope:       nethelpers.ScopeLink,
-			Family:      nethelpers.FamilyInet6,
-		},
-	)
-
-	ipv6gw, err := netip.ParseAddr(strings.Split(interfaceAddresses.IPv6.LinkLocal.String(), ":")[0] + "::1")
-	if err != nil {
-		return nil, err
-	}
-
-	route := network.RouteSpecSpec{
-		ConfigLayer: network.ConfigPlatform,
-		Gateway:     ipv6gw,
-		OutLinkName: "eth0",
-		Destination: interfaceAddresses.IPv6.LinkLocal,
-		Table:       nethelpers.TableMain,
-		Protocol:    nethelpers.ProtocolStatic,
-		Type:        nethelpers.TypeUnicast,
-		Family:      nethelpers.FamilyInet6,
-		Priority:    1024,
-	}
-
-	route.Normalize()
-
-	networkConfig.Routes = append(networkConfig.Routes, route)
-
-	for _, ipStr := range publicIPs {
-		if ip, err := netip.ParseAddr(ipStr); err == nil {
-			networkConfig.ExternalIPs = append(networkConfig.ExternalIPs, ip)
-		}
-	}
-
-	networkConfig.Metadata = &runtimeres.PlatformMetadataSpec{
-		Platform:     a.Name(),
-		Hostname:     metadata.Label,
-		Region:       metadata.Region,
-		InstanceType: metadata.Type,
-		InstanceID:   strconv.Itoa(metadata.ID),
-		ProviderID:   fmt.Sprintf("linode://%d", metadata.ID),
-	}
-
-	return networkConfig, nil
-}
-
 // Configuration implements the platform.Platform interface.
 func (a *Akamai) Configuration(ctx context.Context, r state.State) ([]byte, error) {
 	if err := netutils.Wait(ctx, r); err != nil {
@@ -184,6 +69,12 @@ func (a *Akamai) KernelArgs(string, quirks.Quirks) procfs.Parameters {
 }
 
 // NetworkConfiguration implements the runtime.Platform interface.
+//
+// It polls the metadata service on an interval (overridable via the
+// [metadataIntervalKernelArg] kernel argument), and only pushes a new
+// PlatformNetworkConfig when it differs from the last one sent, so that
+// in-place changes to a Linode's addresses, VLANs, or VPC interfaces are
+// picked up without a reboot.
 func (a *Akamai) NetworkConfiguration(ctx context.Context, r state.State, ch chan<- *runtime.PlatformNetworkConfig) error {
 	// Wait for network to be ready before attempting metadata service calls
 	if err := netutils.Wait(ctx, r); err != nil {
@@ -195,34 +86,42 @@ func (a *Akamai) NetworkConfiguration(ctx context.Context, r state.State, ch cha
 		return fmt.Errorf("new metadata client: %w", err)
 	}
 
+	var uuidEnsured bool
+
+	return pollNetworkConfig(ctx, a.metadataPollInterval(), ch, func() (*runtime.PlatformNetworkConfig, error) {
+		return a.fetchNetworkConfig(ctx, r, metadataClient, &uuidEnsured)
+	})
+}
+
+// fetchNetworkConfig fetches instance and network metadata, ensures the SMBIOS UUID
+// override is set on first success, and parses the result into a PlatformNetworkConfig.
+func (a *Akamai) fetchNetworkConfig(ctx context.Context, r state.State, metadataClient *akametadata.Client, uuidEnsured *bool) (*runtime.PlatformNetworkConfig, error) {
 	metadata, err := metadataClient.GetInstance(ctx)
 	if err != nil {
-		return fmt.Errorf("get instance data: %w", err)
+		return nil, fmt.Errorf("get instance data: %w", err)
 	}
 
-	// Check if SMBIOS UUID needs to be overridden and set UUID from Linode instance ID
-	// This is done here after network is ready and we have the instance metadata
-	if err := a.ensureValidUUID(ctx, r, metadata.ID); err != nil {
-		return fmt.Errorf("failed to ensure valid UUID: %w", err)
+	if !*uuidEnsured {
+		// Check if SMBIOS UUID needs to be overridden and set UUID from Linode instance ID.
+		// This only needs to happen once per boot, after which the override (if any) is persisted.
+		if err := a.ensureValidUUID(ctx, r, metadata.ID); err != nil {
+			return nil, fmt.Errorf("failed to ensure valid UUID: %w", err)
+		}
+
+		*uuidEnsured = true
 	}
 
 	metadataNetworkConfig, err := metadataClient.GetNetwork(ctx)
 	if err != nil {
-		return fmt.Errorf("get network data: %w", err)
+		return nil, fmt.Errorf("get network data: %w", err)
 	}
 
 	networkConfig, err := a.ParseMetadata(metadata, metadataNetworkConfig)
 	if err != nil {
-		return fmt.Errorf("parse metadata: %w", err)
+		return nil, fmt.Errorf("parse metadata: %w", err)
 	}
 
-	select {
-	case ch <- networkConfig:
-	case <-ctx.Done():
-		return ctx.Err()
-	}
-
-	return nil
+	return networkConfig, nil
 }
 
 // ensureValidUUID checks if SMBIOS UUID is valid and sets override if needed.
@@ -274,25 +173,3 @@ func (a *Akamai) ensureValidUUID(ctx context.Context, r state.State, linodeID in
 
 	return nil
 }
-
-// isInvalidUUID checks if a UUID is invalid (empty or all-zeros).
-func isInvalidUUID(uuid string) bool {
-	if uuid == "" {
-		return true
-	}
-
-	// Check for all-zeros UUID (the main issue on Linode VMs)
-	if uuid == "00000000-0000-0000-0000-000000000000" {
-		return true
-	}
-
-	return false
-}
-
-// generateLinodeUUID creates a UUID from Linode instance ID.
-func generateLinodeUUID(linodeID int) string {
-	// Create UUID format: 00000000-0000-0000-0000-{12-digit-linode-id}
-	// Pad the Linode ID to 12 digits with leading zeros
-	linodeIDStr := fmt.Sprintf("%012d", linodeID)
-	return fmt.Sprintf("00000000-0000-0000-0000-%s", linodeIDStr)
-}
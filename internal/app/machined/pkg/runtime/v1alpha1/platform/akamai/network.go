@@ -0,0 +1,306 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package akamai
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	akametadata "github.com/linode/go-metadata"
+
+	"github.com/siderolabs/talos/internal/app/machined/pkg/runtime"
+	"github.com/siderolabs/talos/pkg/machinery/nethelpers"
+	"github.com/siderolabs/talos/pkg/machinery/resources/network"
+	runtimeres "github.com/siderolabs/talos/pkg/machinery/resources/runtime"
+)
+
+// Interface purposes as reported by the Akamai metadata service.
+const (
+	networkInterfacePurposePublic = "public"
+	networkInterfacePurposeVLAN   = "vlan"
+	networkInterfacePurposeVPC    = "vpc"
+)
+
+// ParseMetadata converts Akamai platform metadata into platform network config.
+//
+// Interfaces are enumerated in the order reported by the metadata service and mapped
+// to predictable link names (eth0, eth1, ...). A metadata response with no Interfaces
+// entries is treated as a single public interface on eth0, for compatibility with older
+// metadata API versions.
+func (a *Akamai) ParseMetadata(metadata *akametadata.InstanceData, interfaceAddresses *akametadata.NetworkData) (*runtime.PlatformNetworkConfig, error) {
+	networkConfig := &runtime.PlatformNetworkConfig{}
+
+	if metadata.Label != "" {
+		hostnameSpec := network.HostnameSpecSpec{
+			ConfigLayer: network.ConfigPlatform,
+		}
+
+		if err := hostnameSpec.ParseFQDN(metadata.Label); err != nil {
+			return nil, err
+		}
+
+		networkConfig.Hostnames = append(networkConfig.Hostnames, hostnameSpec)
+	}
+
+	ifaces := interfaceAddresses.Interfaces
+	if len(ifaces) == 0 {
+		ifaces = []akametadata.NetworkInterface{{Purpose: networkInterfacePurposePublic}}
+	}
+
+	var publicIPs []string
+
+	for i, iface := range ifaces {
+		linkName := fmt.Sprintf("eth%d", i)
+
+		networkConfig.Links = append(networkConfig.Links, linkSpecForInterface(linkName, iface))
+
+		switch iface.Purpose {
+		case networkInterfacePurposeVLAN:
+			addr, err := addressSpecForIPAM(linkName, iface.IPAMAddress)
+			if err != nil {
+				return nil, fmt.Errorf("parse VLAN address for %s: %w", linkName, err)
+			}
+
+			if addr != nil {
+				networkConfig.Addresses = append(networkConfig.Addresses, *addr)
+			}
+		case networkInterfacePurposeVPC:
+			addr, err := addressSpecForIPAM(linkName, iface.IPAMAddress)
+			if err != nil {
+				return nil, fmt.Errorf("parse VPC address for %s: %w", linkName, err)
+			}
+
+			if addr != nil {
+				networkConfig.Addresses = append(networkConfig.Addresses, *addr)
+				networkConfig.Routes = append(networkConfig.Routes, subnetRouteSpec(linkName, addr.Address))
+			}
+		default:
+			// networkInterfacePurposePublic, and any purpose we don't recognize yet, fall back
+			// to the legacy public-interface behavior driven by the top-level IPv4/IPv6 fields.
+			addrs, route, ips, err := parsePublicInterface(linkName, interfaceAddresses)
+			if err != nil {
+				return nil, err
+			}
+
+			networkConfig.Addresses = append(networkConfig.Addresses, addrs...)
+			networkConfig.Routes = append(networkConfig.Routes, route)
+			publicIPs = append(publicIPs, ips...)
+		}
+	}
+
+	for _, ipStr := range publicIPs {
+		if ip, err := netip.ParseAddr(ipStr); err == nil {
+			networkConfig.ExternalIPs = append(networkConfig.ExternalIPs, ip)
+		}
+	}
+
+	if resolverSpec := resolverSpecForDNS(interfaceAddresses.DNS); resolverSpec != nil {
+		networkConfig.Resolvers = append(networkConfig.Resolvers, *resolverSpec)
+	}
+
+	networkConfig.Metadata = &runtimeres.PlatformMetadataSpec{
+		Platform:     a.Name(),
+		Hostname:     metadata.Label,
+		Region:       metadata.Region,
+		InstanceType: metadata.Type,
+		InstanceID:   strconv.Itoa(metadata.ID),
+		ProviderID:   fmt.Sprintf("linode://%d", metadata.ID),
+	}
+
+	return networkConfig, nil
+}
+
+// parsePublicInterface builds the address, route, and external IP specs for the
+// public-facing interface from the legacy top-level IPv4/IPv6 metadata fields.
+func parsePublicInterface(linkName string, interfaceAddresses *akametadata.NetworkData) ([]network.AddressSpecSpec, network.RouteSpecSpec, []string, error) {
+	var addresses []network.AddressSpecSpec
+
+	publicIPs := make([]string, 0, len(interfaceAddresses.IPv4.Public)+len(interfaceAddresses.IPv6.Ranges))
+
+	for _, iface := range interfaceAddresses.IPv4.Public {
+		publicIPs = append(publicIPs, iface.Addr().String())
+		addresses = append(addresses, network.AddressSpecSpec{
+			ConfigLayer: network.ConfigPlatform,
+			LinkName:    linkName,
+			Address:     iface,
+			Scope:       nethelpers.ScopeGlobal,
+			Flags:       nethelpers.AddressFlags(nethelpers.AddressPermanent),
+			Family:      nethelpers.FamilyInet4,
+		})
+	}
+
+	for _, iface := range interfaceAddresses.IPv4.Private {
+		addresses = append(addresses, network.AddressSpecSpec{
+			ConfigLayer: network.ConfigPlatform,
+			LinkName:    linkName,
+			Address:     iface,
+			Scope:       nethelpers.ScopeGlobal,
+			Flags:       nethelpers.AddressFlags(nethelpers.AddressPermanent),
+			Family:      nethelpers.FamilyInet4,
+		})
+	}
+
+	for _, iface := range interfaceAddresses.IPv6.Ranges {
+		publicIPs = append(publicIPs, iface.Addr().String())
+
+		addresses = append(addresses, network.AddressSpecSpec{
+			ConfigLayer: network.ConfigPlatform,
+			LinkName:    linkName,
+			Address:     iface,
+			Scope:       nethelpers.ScopeGlobal,
+			Flags:       nethelpers.AddressFlags(nethelpers.AddressManagementTemp),
+			Family:      nethelpers.FamilyInet6,
+		})
+	}
+
+	addresses = append(addresses, network.AddressSpecSpec{
+		ConfigLayer: network.ConfigPlatform,
+		LinkName:    linkName,
+		Address:     interfaceAddresses.IPv6.LinkLocal,
+		Scope:       nethelpers.ScopeLink,
+		Family:      nethelpers.FamilyInet6,
+	})
+
+	ipv6gw, err := netip.ParseAddr(strings.Split(interfaceAddresses.IPv6.LinkLocal.String(), ":")[0] + "::1")
+	if err != nil {
+		return nil, network.RouteSpecSpec{}, nil, err
+	}
+
+	route := network.RouteSpecSpec{
+		ConfigLayer: network.ConfigPlatform,
+		Gateway:     ipv6gw,
+		OutLinkName: linkName,
+		Destination: interfaceAddresses.IPv6.LinkLocal,
+		Table:       nethelpers.TableMain,
+		Protocol:    nethelpers.ProtocolStatic,
+		Type:        nethelpers.TypeUnicast,
+		Family:      nethelpers.FamilyInet6,
+		Priority:    1024,
+	}
+
+	route.Normalize()
+
+	return addresses, route, publicIPs, nil
+}
+
+// addressSpecForIPAM parses a CIDR string reported by the metadata service (a VLAN or
+// VPC interface's IPAMAddress) into an AddressSpecSpec. It returns nil if ipam is empty,
+// which happens for interfaces that are attached but have no address assigned yet.
+func addressSpecForIPAM(linkName, ipam string) (*network.AddressSpecSpec, error) {
+	if ipam == "" {
+		return nil, nil
+	}
+
+	prefix, err := netip.ParsePrefix(ipam)
+	if err != nil {
+		return nil, err
+	}
+
+	family := nethelpers.FamilyInet4
+	if prefix.Addr().Is6() {
+		family = nethelpers.FamilyInet6
+	}
+
+	return &network.AddressSpecSpec{
+		ConfigLayer: network.ConfigPlatform,
+		LinkName:    linkName,
+		Address:     prefix,
+		Scope:       nethelpers.ScopeGlobal,
+		Flags:       nethelpers.AddressFlags(nethelpers.AddressPermanent),
+		Family:      family,
+	}, nil
+}
+
+// subnetRouteSpec builds an on-link route for a VPC interface's subnet, so the rest of
+// the VPC is reachable even though the metadata service doesn't report an explicit
+// gateway address for it.
+func subnetRouteSpec(linkName string, prefix netip.Prefix) network.RouteSpecSpec {
+	family := nethelpers.FamilyInet4
+	if prefix.Addr().Is6() {
+		family = nethelpers.FamilyInet6
+	}
+
+	route := network.RouteSpecSpec{
+		ConfigLayer: network.ConfigPlatform,
+		OutLinkName: linkName,
+		Destination: prefix.Masked(),
+		Table:       nethelpers.TableMain,
+		Protocol:    nethelpers.ProtocolStatic,
+		Type:        nethelpers.TypeUnicast,
+		Family:      family,
+	}
+
+	route.Normalize()
+
+	return route
+}
+
+// linkSpecForInterface builds the LinkSpecSpec for an interface. Like the public and
+// VPC interfaces, a Linode VLAN interface presents to the guest as an ordinary NIC the
+// kernel already created, not an 802.1Q sub-interface Talos must fabricate, so it is not
+// marked Logical here either. It still uses ConfigPlatform like every other spec in this
+// file, so that user machine config can override it.
+func linkSpecForInterface(linkName string, iface akametadata.NetworkInterface) network.LinkSpecSpec {
+	spec := network.LinkSpecSpec{
+		ConfigLayer: network.ConfigPlatform,
+		Name:        linkName,
+		Up:          true,
+	}
+
+	if iface.MTU > 0 {
+		spec.MTU = uint32(iface.MTU)
+	}
+
+	if iface.MAC != "" {
+		if mac, err := net.ParseMAC(iface.MAC); err == nil {
+			spec.HardwareAddr = nethelpers.HardwareAddr(mac)
+		}
+	}
+
+	return spec
+}
+
+// resolverSpecForDNS translates the metadata service's DNS data into a ResolverSpecSpec,
+// deduplicating nameservers while preserving order. It returns nil if the metadata
+// client reported no DNS data (e.g. on older metadata API versions), so that
+// DHCP-provided resolvers are honored instead of being overridden by an empty spec.
+func resolverSpecForDNS(dns akametadata.NetworkDNSData) *network.ResolverSpecSpec {
+	if len(dns.Nameservers) == 0 && len(dns.SearchDomains) == 0 {
+		return nil
+	}
+
+	resolverSpec := &network.ResolverSpecSpec{
+		ConfigLayer: network.ConfigPlatform,
+	}
+
+	seenServers := make(map[netip.Addr]struct{}, len(dns.Nameservers))
+
+	for _, server := range dns.Nameservers {
+		if _, ok := seenServers[server]; ok {
+			continue
+		}
+
+		seenServers[server] = struct{}{}
+
+		resolverSpec.DNSServers = append(resolverSpec.DNSServers, server)
+	}
+
+	seenDomains := make(map[string]struct{}, len(dns.SearchDomains))
+
+	for _, domain := range dns.SearchDomains {
+		if _, ok := seenDomains[domain]; ok {
+			continue
+		}
+
+		seenDomains[domain] = struct{}{}
+
+		resolverSpec.SearchDomains = append(resolverSpec.SearchDomains, domain)
+	}
+
+	return resolverSpec
+}
@@ -0,0 +1,192 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package akamai
+
+import (
+	"net/netip"
+	"testing"
+
+	akametadata "github.com/linode/go-metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siderolabs/talos/pkg/machinery/resources/network"
+)
+
+func TestParseMetadataInterfaces(t *testing.T) {
+	metadata := &akametadata.InstanceData{
+		ID:     79475478,
+		Label:  "test-instance",
+		Region: "us-east",
+		Type:   "g6-standard-2",
+	}
+
+	linkLocal := netip.MustParsePrefix("fe80::1234/64")
+
+	baseNetworkData := func(ifaces ...akametadata.NetworkInterface) *akametadata.NetworkData {
+		return &akametadata.NetworkData{
+			IPv4: akametadata.NetworkIPv4Data{
+				Public:  []netip.Prefix{netip.MustParsePrefix("203.0.113.5/24")},
+				Private: []netip.Prefix{netip.MustParsePrefix("192.168.128.5/17")},
+			},
+			IPv6: akametadata.NetworkIPv6Data{
+				Ranges:    []netip.Prefix{netip.MustParsePrefix("2600:3c00::f03c:93ff:fe00:1234/64")},
+				LinkLocal: linkLocal,
+			},
+			Interfaces: ifaces,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		networkData   *akametadata.NetworkData
+		wantLinkNames []string
+		wantAddresses int
+		wantRoutes    int
+	}{
+		{
+			name:          "single public interface (backwards compat)",
+			networkData:   baseNetworkData(),
+			wantLinkNames: []string{"eth0"},
+			// public + private + ipv6 range + ipv6 link-local
+			wantAddresses: 4,
+			// ipv6 link-local route
+			wantRoutes: 1,
+		},
+		{
+			name: "public and VLAN",
+			networkData: baseNetworkData(
+				akametadata.NetworkInterface{Purpose: networkInterfacePurposePublic},
+				akametadata.NetworkInterface{Purpose: networkInterfacePurposeVLAN, Label: "vlan1", IPAMAddress: "10.0.0.2/24"},
+			),
+			wantLinkNames: []string{"eth0", "eth1"},
+			wantAddresses: 5, // 4 public + 1 VLAN
+			wantRoutes:    1,
+		},
+		{
+			name: "public and VPC",
+			networkData: baseNetworkData(
+				akametadata.NetworkInterface{Purpose: networkInterfacePurposePublic},
+				akametadata.NetworkInterface{Purpose: networkInterfacePurposeVPC, IPAMAddress: "10.10.0.5/24"},
+			),
+			wantLinkNames: []string{"eth0", "eth1"},
+			wantAddresses: 5, // 4 public + 1 VPC
+			wantRoutes:    2, // ipv6 link-local route + VPC subnet route
+		},
+	}
+
+	a := &Akamai{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := a.ParseMetadata(metadata, tt.networkData)
+			require.NoError(t, err)
+
+			linkNames := make([]string, 0, len(config.Links))
+			for _, link := range config.Links {
+				linkNames = append(linkNames, link.Name)
+			}
+
+			assert.Equal(t, tt.wantLinkNames, linkNames)
+			assert.Len(t, config.Addresses, tt.wantAddresses)
+			assert.Len(t, config.Routes, tt.wantRoutes)
+		})
+	}
+}
+
+func TestParseMetadataVLANMarkedConfigPlatform(t *testing.T) {
+	metadata := &akametadata.InstanceData{ID: 1}
+	networkData := &akametadata.NetworkData{
+		IPv6: akametadata.NetworkIPv6Data{LinkLocal: netip.MustParsePrefix("fe80::1/64")},
+		Interfaces: []akametadata.NetworkInterface{
+			{Purpose: networkInterfacePurposePublic},
+			{Purpose: networkInterfacePurposeVLAN, IPAMAddress: "10.0.0.2/24"},
+		},
+	}
+
+	a := &Akamai{}
+
+	config, err := a.ParseMetadata(metadata, networkData)
+	require.NoError(t, err)
+
+	for _, addr := range config.Addresses {
+		assert.Equal(t, network.ConfigPlatform, addr.ConfigLayer)
+	}
+
+	for _, link := range config.Links {
+		assert.Equal(t, network.ConfigPlatform, link.ConfigLayer)
+	}
+}
+
+func TestParseMetadataVPCSubnetRoute(t *testing.T) {
+	metadata := &akametadata.InstanceData{ID: 1}
+	networkData := &akametadata.NetworkData{
+		IPv6: akametadata.NetworkIPv6Data{LinkLocal: netip.MustParsePrefix("fe80::1/64")},
+		Interfaces: []akametadata.NetworkInterface{
+			{Purpose: networkInterfacePurposePublic},
+			{Purpose: networkInterfacePurposeVPC, IPAMAddress: "10.10.0.5/24"},
+		},
+	}
+
+	a := &Akamai{}
+
+	config, err := a.ParseMetadata(metadata, networkData)
+	require.NoError(t, err)
+
+	var vpcRoute *network.RouteSpecSpec
+
+	for i := range config.Routes {
+		if config.Routes[i].OutLinkName == "eth1" {
+			vpcRoute = &config.Routes[i]
+		}
+	}
+
+	require.NotNil(t, vpcRoute, "expected a subnet route for the VPC interface")
+	assert.Equal(t, netip.MustParsePrefix("10.10.0.0/24"), vpcRoute.Destination)
+}
+
+func TestParseMetadataDNS(t *testing.T) {
+	metadata := &akametadata.InstanceData{ID: 1}
+
+	t.Run("nameservers and search domains are translated and deduplicated", func(t *testing.T) {
+		networkData := &akametadata.NetworkData{
+			IPv6: akametadata.NetworkIPv6Data{LinkLocal: netip.MustParsePrefix("fe80::1/64")},
+			DNS: akametadata.NetworkDNSData{
+				Nameservers: []netip.Addr{
+					netip.MustParseAddr("172.233.80.1"),
+					netip.MustParseAddr("2600:3c00::1"),
+					netip.MustParseAddr("172.233.80.1"), // duplicate, should be dropped
+				},
+				SearchDomains: []string{"members.linode.com", "members.linode.com"},
+			},
+		}
+
+		a := &Akamai{}
+
+		config, err := a.ParseMetadata(metadata, networkData)
+		require.NoError(t, err)
+		require.Len(t, config.Resolvers, 1)
+
+		resolver := config.Resolvers[0]
+		assert.Equal(t, network.ConfigPlatform, resolver.ConfigLayer)
+		assert.Equal(t, []netip.Addr{
+			netip.MustParseAddr("172.233.80.1"),
+			netip.MustParseAddr("2600:3c00::1"),
+		}, resolver.DNSServers)
+		assert.Equal(t, []string{"members.linode.com"}, resolver.SearchDomains)
+	})
+
+	t.Run("no DNS data falls back to no resolver spec", func(t *testing.T) {
+		networkData := &akametadata.NetworkData{
+			IPv6: akametadata.NetworkIPv6Data{LinkLocal: netip.MustParsePrefix("fe80::1/64")},
+		}
+
+		a := &Akamai{}
+
+		config, err := a.ParseMetadata(metadata, networkData)
+		require.NoError(t, err)
+		assert.Empty(t, config.Resolvers)
+	})
+}
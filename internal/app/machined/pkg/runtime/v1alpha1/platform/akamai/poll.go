@@ -0,0 +1,125 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package akamai
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"reflect"
+	"time"
+
+	"github.com/siderolabs/go-procfs/procfs"
+
+	"github.com/siderolabs/talos/internal/app/machined/pkg/runtime"
+)
+
+const (
+	// metadataIntervalKernelArg overrides the default interval between Akamai metadata
+	// polls, e.g. talos.platform.akamai.metadata_interval=30s.
+	metadataIntervalKernelArg = "talos.platform.akamai.metadata_interval"
+
+	defaultMetadataPollInterval = 15 * time.Minute
+	metadataPollJitter          = 0.1
+
+	// metadataPollInitialBackoff is the retry delay after the first transient error.
+	// It is deliberately independent of the poll interval so that a brief blip still
+	// ramps up gradually instead of jumping straight to metadataPollMaxBackoff.
+	metadataPollInitialBackoff = time.Second
+	metadataPollMaxBackoff     = 5 * time.Minute
+)
+
+// metadataPollInterval returns the interval at which the metadata service should be
+// polled for changes, honoring the [metadataIntervalKernelArg] kernel argument if set.
+func (a *Akamai) metadataPollInterval() time.Duration {
+	value, ok := procfs.ProcCmdline().Get(metadataIntervalKernelArg).First()
+	if !ok {
+		return defaultMetadataPollInterval
+	}
+
+	interval, err := time.ParseDuration(*value)
+	if err != nil || interval <= 0 {
+		return defaultMetadataPollInterval
+	}
+
+	return interval
+}
+
+// pollNetworkConfig calls fetch on interval (with jitter), pushing the result onto ch
+// only when it differs from the last value successfully pushed. fetch errors are
+// retried with exponential backoff, starting at metadataPollInitialBackoff and capped
+// at metadataPollMaxBackoff, regardless of interval. It returns ctx.Err() once ctx is
+// done.
+func pollNetworkConfig(ctx context.Context, interval time.Duration, ch chan<- *runtime.PlatformNetworkConfig, fetch func() (*runtime.PlatformNetworkConfig, error)) error {
+	var lastConfig *runtime.PlatformNetworkConfig
+
+	backoff := metadataPollInitialBackoff
+
+	for {
+		networkConfig, err := fetch()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			log.Printf("akamai: failed to refresh metadata, will retry: %s", err)
+
+			if waitErr := sleepWithJitter(ctx, backoff); waitErr != nil {
+				return waitErr
+			}
+
+			backoff = nextBackoff(backoff, metadataPollMaxBackoff)
+
+			continue
+		}
+
+		backoff = metadataPollInitialBackoff
+
+		if lastConfig == nil || !reflect.DeepEqual(lastConfig, networkConfig) {
+			select {
+			case ch <- networkConfig:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+
+			lastConfig = networkConfig
+		}
+
+		if err := sleepWithJitter(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// nextBackoff doubles current, capped at max.
+func nextBackoff(current, max time.Duration) time.Duration {
+	next := current * 2
+
+	if next <= 0 || next > max {
+		return max
+	}
+
+	return next
+}
+
+// sleepWithJitter sleeps for approximately d, adding up to metadataPollJitter*d of
+// random jitter, returning early with ctx.Err() if ctx is done first.
+func sleepWithJitter(ctx context.Context, d time.Duration) error {
+	wait := d
+
+	if jitterMax := time.Duration(float64(d) * metadataPollJitter); jitterMax > 0 {
+		wait += time.Duration(rand.Int63n(int64(jitterMax))) //nolint:gosec
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,142 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package akamai
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siderolabs/talos/internal/app/machined/pkg/runtime"
+	"github.com/siderolabs/talos/pkg/machinery/resources/network"
+)
+
+func TestNextBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  time.Duration
+		max      time.Duration
+		expected time.Duration
+	}{
+		{
+			name:     "doubles when well under the cap",
+			current:  time.Second,
+			max:      metadataPollMaxBackoff,
+			expected: 2 * time.Second,
+		},
+		{
+			name:     "ramps gradually from the initial backoff toward the cap",
+			current:  metadataPollInitialBackoff,
+			max:      metadataPollMaxBackoff,
+			expected: 2 * metadataPollInitialBackoff,
+		},
+		{
+			name:     "clamps to the cap once doubling would exceed it",
+			current:  4 * time.Minute,
+			max:      metadataPollMaxBackoff,
+			expected: metadataPollMaxBackoff,
+		},
+		{
+			name:     "stays at the cap once reached",
+			current:  metadataPollMaxBackoff,
+			max:      metadataPollMaxBackoff,
+			expected: metadataPollMaxBackoff,
+		},
+		{
+			name:     "default poll interval does not skip straight to the cap",
+			current:  metadataPollInitialBackoff,
+			max:      defaultMetadataPollInterval,
+			expected: 2 * metadataPollInitialBackoff,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, nextBackoff(tt.current, tt.max))
+		})
+	}
+}
+
+func TestPollNetworkConfigPushesOnlyOnChange(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ch := make(chan *runtime.PlatformNetworkConfig, 2)
+
+	configA := &runtime.PlatformNetworkConfig{Hostnames: []network.HostnameSpecSpec{{Hostname: "a"}}}
+	configB := &runtime.PlatformNetworkConfig{Hostnames: []network.HostnameSpecSpec{{Hostname: "b"}}}
+
+	responses := []*runtime.PlatformNetworkConfig{configA, configA, configB, configB}
+
+	var calls atomic.Int32
+
+	fetch := func() (*runtime.PlatformNetworkConfig, error) {
+		i := calls.Add(1) - 1
+		if int(i) >= len(responses) {
+			cancel()
+
+			return nil, ctx.Err()
+		}
+
+		return responses[i], nil
+	}
+
+	err := pollNetworkConfig(ctx, time.Millisecond, ch, fetch)
+	require.ErrorIs(t, err, context.Canceled)
+
+	received := []*runtime.PlatformNetworkConfig{<-ch, <-ch}
+	assert.Equal(t, []*runtime.PlatformNetworkConfig{configA, configB}, received)
+
+	select {
+	case cfg := <-ch:
+		t.Fatalf("unexpected extra config pushed: %+v", cfg)
+	default:
+	}
+}
+
+func TestPollNetworkConfigExitsCleanlyOnContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ch := make(chan *runtime.PlatformNetworkConfig)
+
+	fetch := func() (*runtime.PlatformNetworkConfig, error) {
+		cancel()
+
+		return &runtime.PlatformNetworkConfig{}, nil
+	}
+
+	err := pollNetworkConfig(ctx, time.Hour, ch, fetch)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestPollNetworkConfigRetriesTransientErrors(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ch := make(chan *runtime.PlatformNetworkConfig, 1)
+
+	var calls atomic.Int32
+
+	errBoom := errors.New("transient metadata error")
+
+	fetch := func() (*runtime.PlatformNetworkConfig, error) {
+		if calls.Add(1) <= 2 {
+			return nil, errBoom
+		}
+
+		cancel()
+
+		return &runtime.PlatformNetworkConfig{}, nil
+	}
+
+	err := pollNetworkConfig(ctx, time.Hour, ch, fetch)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.GreaterOrEqual(t, calls.Load(), int32(3))
+}
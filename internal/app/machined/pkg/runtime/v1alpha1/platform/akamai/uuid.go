@@ -0,0 +1,73 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package akamai
+
+import (
+	"crypto/sha1" //nolint:gosec
+	"encoding/hex"
+	"fmt"
+)
+
+// akamaiNamespace is the namespace UUID used to derive deterministic RFC 4122 v5 UUIDs
+// for Linode instances. It is a fixed, randomly generated UUID dedicated to this
+// platform integration and must never change, as doing so would change the generated
+// instance UUIDs across Talos upgrades.
+var akamaiNamespace = [16]byte{
+	0x1d, 0x4b, 0x5a, 0x8e, 0x9f, 0x2c, 0x4e, 0x1a,
+	0xb3, 0x7d, 0x6a, 0x8f, 0x0c, 0x2e, 0x9b, 0x44,
+}
+
+// generateLinodeUUID derives a deterministic RFC 4122 v5 (SHA-1, namespace-based) UUID
+// from the Linode instance ID. The result is used to override a missing or invalid
+// SMBIOS UUID so that downstream consumers always observe a well-formed UUID.
+func generateLinodeUUID(linodeID int) string {
+	name := fmt.Sprintf("linode:%d", linodeID)
+
+	h := sha1.New() //nolint:gosec
+	h.Write(akamaiNamespace[:])
+	h.Write([]byte(name))
+
+	sum := h.Sum(nil)
+
+	sum[6] = (sum[6] & 0x0f) | 0x50 // version 5
+	sum[8] = (sum[8] & 0x3f) | 0x80 // RFC 4122 variant
+
+	hexSum := hex.EncodeToString(sum[:16])
+
+	return fmt.Sprintf("%s-%s-%s-%s-%s", hexSum[0:8], hexSum[8:12], hexSum[12:16], hexSum[16:20], hexSum[20:32])
+}
+
+// isInvalidUUID checks if a UUID is invalid: empty, all-zeros, or not a well-formed
+// RFC 4122 UUID with a version in the range v1-v5.
+func isInvalidUUID(uuid string) bool {
+	if uuid == "" {
+		return true
+	}
+
+	if uuid == "00000000-0000-0000-0000-000000000000" {
+		return true
+	}
+
+	return !isRFC4122UUID(uuid)
+}
+
+// isRFC4122UUID reports whether uuid is syntactically a canonical 8-4-4-4-12 UUID whose
+// version and variant nibbles match RFC 4122 (version 1 through 5).
+func isRFC4122UUID(uuid string) bool {
+	if len(uuid) != 36 || uuid[8] != '-' || uuid[13] != '-' || uuid[18] != '-' || uuid[23] != '-' {
+		return false
+	}
+
+	raw := uuid[0:8] + uuid[9:13] + uuid[14:18] + uuid[19:23] + uuid[24:36]
+
+	b, err := hex.DecodeString(raw)
+	if err != nil || len(b) != 16 {
+		return false
+	}
+
+	version := b[6] >> 4
+
+	return version >= 1 && version <= 5 && b[8]&0xc0 == 0x80
+}
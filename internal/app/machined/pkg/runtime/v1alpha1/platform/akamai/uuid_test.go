@@ -30,22 +30,22 @@ func TestGenerateLinodeUUID(t *testing.T) {
 		{
 			name:     "small ID",
 			linodeID: 123,
-			expected: "00000000-0000-0000-0000-000000000123",
+			expected: "19f68164-c5ed-5504-b3e8-978b169f2b79",
 		},
 		{
 			name:     "medium ID",
 			linodeID: 79475478,
-			expected: "00000000-0000-0000-0000-000079475478",
+			expected: "2332c7e9-d918-5bdf-b5b8-b68ad4264167",
 		},
 		{
 			name:     "large ID",
 			linodeID: 999999999999,
-			expected: "00000000-0000-0000-0000-999999999999",
+			expected: "b049b40f-60ce-571e-82f5-a2777b234ce3",
 		},
 		{
 			name:     "single digit",
 			linodeID: 1,
-			expected: "00000000-0000-0000-0000-000000000001",
+			expected: "ebeea885-5b16-58a1-9daa-1f6d96fcd0bf",
 		},
 	}
 
@@ -54,12 +54,12 @@ func TestGenerateLinodeUUID(t *testing.T) {
 			result := generateLinodeUUID(tt.linodeID)
 			assert.Equal(t, tt.expected, result)
 
-			// Verify it's a valid UUID format (length and dashes)
-			assert.Len(t, result, 36, "UUID should be 36 characters long")
-			assert.Equal(t, byte('-'), result[8], "8th character should be dash")
-			assert.Equal(t, byte('-'), result[13], "13th character should be dash")
-			assert.Equal(t, byte('-'), result[18], "18th character should be dash")
-			assert.Equal(t, byte('-'), result[23], "23rd character should be dash")
+			// result should be deterministic across calls
+			assert.Equal(t, result, generateLinodeUUID(tt.linodeID))
+
+			// and it should be a well-formed RFC 4122 v5 UUID
+			assert.False(t, isInvalidUUID(result), "generated UUID should be a valid RFC 4122 UUID")
+			assert.Equal(t, byte('5'), result[14], "version nibble should be 5")
 		})
 	}
 }
@@ -87,7 +87,7 @@ func TestIsInvalidUUID(t *testing.T) {
 		},
 		{
 			name:     "generated Linode UUID",
-			uuid:     "00000000-0000-0000-0000-000079475478",
+			uuid:     generateLinodeUUID(79475478),
 			expected: false,
 		},
 		{
@@ -95,6 +95,26 @@ func TestIsInvalidUUID(t *testing.T) {
 			uuid:     "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
 			expected: false,
 		},
+		{
+			name:     "garbage but nonzero legacy-style SMBIOS UUID",
+			uuid:     "00000000-0000-0000-0000-000079475478",
+			expected: true,
+		},
+		{
+			name:     "wrong version nibble",
+			uuid:     "550e8400-e29b-01d4-a716-446655440000",
+			expected: true,
+		},
+		{
+			name:     "wrong variant nibble",
+			uuid:     "550e8400-e29b-51d4-0716-446655440000",
+			expected: true,
+		},
+		{
+			name:     "not a UUID at all",
+			uuid:     "not-a-uuid",
+			expected: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -145,7 +165,7 @@ func TestEnsureValidUUID(t *testing.T) {
 		require.NoError(t, err)
 
 		uuidKey := metaKey.(*runtimeres.MetaKey)
-		assert.Equal(t, "00000000-0000-0000-0000-000079475478", uuidKey.TypedSpec().Value)
+		assert.Equal(t, generateLinodeUUID(79475478), uuidKey.TypedSpec().Value)
 	})
 
 	t.Run("override created when system info not available", func(t *testing.T) {
@@ -161,7 +181,7 @@ func TestEnsureValidUUID(t *testing.T) {
 		require.NoError(t, err)
 
 		uuidKey := metaKey.(*runtimeres.MetaKey)
-		assert.Equal(t, "00000000-0000-0000-0000-000000012345", uuidKey.TypedSpec().Value)
+		assert.Equal(t, generateLinodeUUID(12345), uuidKey.TypedSpec().Value)
 	})
 
 	t.Run("existing override not modified", func(t *testing.T) {
@@ -242,8 +262,7 @@ func TestEnsureValidUUIDIntegration(t *testing.T) {
 			require.NoError(t, err)
 
 			uuidKey := metaKey.(*runtimeres.MetaKey)
-			expectedUUID := fmt.Sprintf("00000000-0000-0000-0000-%012d", linodeID)
-			assert.Equal(t, expectedUUID, uuidKey.TypedSpec().Value)
+			assert.Equal(t, generateLinodeUUID(linodeID), uuidKey.TypedSpec().Value)
 		})
 	}
 }